@@ -0,0 +1,106 @@
+// Package cache persists resolved licenses on disk, keyed by repo, so
+// repeated gh-licenses runs over the same go.sum don't re-spend GitHub's
+// rate limit on dependencies that haven't changed.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Entry is a single cached license lookup result.
+type Entry struct {
+	Key        string    `json:"key"`
+	Name       string    `json:"name"`
+	URL        string    `json:"url"`
+	SPDXID     string    `json:"spdx_id"`
+	Confidence float64   `json:"confidence"`
+	Source     string    `json:"source"`
+	FetchedAt  time.Time `json:"fetched_at"`
+}
+
+func path(owner, repo string) (string, error) {
+	owner, err := sanitizeComponent(owner)
+	if err != nil {
+		return "", fmt.Errorf("owner: %w", err)
+	}
+
+	repo, err = sanitizeComponent(repo)
+	if err != nil {
+		return "", fmt.Errorf("repo: %w", err)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(home, ".cache", "gh-licenses", owner, repo+".json"), nil
+}
+
+// sanitizeComponent rejects owner/repo values that could escape the cache
+// directory when joined into a path, e.g. "../../etc" or a value containing
+// a path separator. Both come from parsed manifests and scanned text, so
+// they must never be trusted enough to build a filesystem path directly.
+func sanitizeComponent(s string) (string, error) {
+	if s == "" || s == "." || s == ".." {
+		return "", fmt.Errorf("invalid path component %q", s)
+	}
+
+	if strings.ContainsAny(s, `/\`) {
+		return "", fmt.Errorf("invalid path component %q", s)
+	}
+
+	return s, nil
+}
+
+// Get returns the cached entry for owner/repo if one exists and is younger
+// than ttl (a ttl of 0 means entries never expire). The returned bool is
+// false on a miss: not found, unreadable, or expired.
+func Get(owner, repo string, ttl time.Duration) (Entry, bool) {
+	p, err := path(owner, repo)
+	if err != nil {
+		return Entry{}, false
+	}
+
+	data, err := ioutil.ReadFile(p)
+	if err != nil {
+		return Entry{}, false
+	}
+
+	var entry Entry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return Entry{}, false
+	}
+
+	if ttl > 0 && time.Since(entry.FetchedAt) > ttl {
+		return Entry{}, false
+	}
+
+	return entry, true
+}
+
+// Set writes entry to the on-disk cache for owner/repo, creating parent
+// directories as needed.
+func Set(owner, repo string, entry Entry) error {
+	p, err := path(owner, repo)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(p, data, 0o644)
+}
@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSetThenGetRoundTrips(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	entry := Entry{
+		Key:       "mit",
+		Name:      "MIT License",
+		URL:       "https://api.github.com/licenses/mit",
+		SPDXID:    "MIT",
+		Source:    "api",
+		FetchedAt: time.Now(),
+	}
+
+	if err := Set("owner", "repo", entry); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	got, ok := Get("owner", "repo", time.Hour)
+	if !ok {
+		t.Fatalf("Get: expected a cache hit")
+	}
+	if got.SPDXID != entry.SPDXID || got.Key != entry.Key {
+		t.Errorf("Get = %+v, want %+v", got, entry)
+	}
+}
+
+func TestGetMissWhenNotCached(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if _, ok := Get("owner", "never-cached", time.Hour); ok {
+		t.Errorf("Get: expected a cache miss")
+	}
+}
+
+func TestSetRejectsPathTraversal(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	tests := []struct {
+		owner string
+		repo  string
+	}{
+		{"..", "../../../../tmp/poc_cache_escape/pwned"},
+		{"owner", "../escape"},
+		{"owner/../escape", "repo"},
+		{"owner", "sub/repo"},
+	}
+
+	for _, tt := range tests {
+		if err := Set(tt.owner, tt.repo, Entry{SPDXID: "MIT"}); err == nil {
+			t.Errorf("Set(%q, %q): expected an error, got nil", tt.owner, tt.repo)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(home, "..", "tmp", "poc_cache_escape")); !os.IsNotExist(err) {
+		t.Errorf("Set escaped the cache directory: %v", err)
+	}
+}
+
+func TestGetExpiresAfterTTL(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	entry := Entry{SPDXID: "MIT", FetchedAt: time.Now().Add(-2 * time.Hour)}
+	if err := Set("owner", "repo", entry); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+
+	if _, ok := Get("owner", "repo", time.Hour); ok {
+		t.Errorf("Get: expected a miss for an expired entry")
+	}
+
+	// A ttl of 0 means entries never expire.
+	if _, ok := Get("owner", "repo", 0); !ok {
+		t.Errorf("Get: expected a hit when ttl is 0")
+	}
+}
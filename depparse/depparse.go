@@ -0,0 +1,35 @@
+// Package depparse extracts the dependencies named in a Go dependency
+// manifest, dispatching on the manifest's filename to a format-specific
+// parser rather than scanning every file with one generic regex.
+package depparse
+
+import "path/filepath"
+
+// Dependency is a single entry from a manifest, carrying enough information
+// for the reporter to print the exact version (or commit) whose license was
+// resolved.
+type Dependency struct {
+	ImportPath string
+	Version    string
+	Revision   string
+	// Direct is true when the manifest marks this dependency as directly
+	// imported, as opposed to pulled in transitively.
+	Direct bool
+}
+
+// ParseFile parses the manifest at path, dispatching on its base filename.
+// It returns (nil, nil) for filenames none of the supported parsers handle.
+func ParseFile(path string) ([]Dependency, error) {
+	switch filepath.Base(path) {
+	case "go.mod":
+		return parseGoMod(path)
+	case "go.sum":
+		return parseGoSum(path)
+	case "Godeps.json":
+		return parseGodeps(path)
+	case "modules.txt":
+		return parseVendorModules(path)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,23 @@
+package depparse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseFileUnknownFilenameReturnsNil(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "README.md")
+	if err := os.WriteFile(path, []byte("github.com/pkg/errors"), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	deps, err := ParseFile(path)
+	if err != nil {
+		t.Fatalf("ParseFile: unexpected error: %v", err)
+	}
+	if deps != nil {
+		t.Errorf("ParseFile(%q) = %+v, want nil", path, deps)
+	}
+}
@@ -0,0 +1,38 @@
+package depparse
+
+import (
+	"encoding/json"
+	"io/ioutil"
+)
+
+// godepsFile mirrors the shape gosupplychain.LoadGodepsFile expects:
+// Deps[].ImportPath and Deps[].Rev.
+type godepsFile struct {
+	Deps []struct {
+		ImportPath string `json:"ImportPath"`
+		Rev        string `json:"Rev"`
+	} `json:"Deps"`
+}
+
+func parseGodeps(path string) ([]Dependency, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var gf godepsFile
+	if err := json.Unmarshal(data, &gf); err != nil {
+		return nil, err
+	}
+
+	deps := make([]Dependency, 0, len(gf.Deps))
+	for _, dep := range gf.Deps {
+		deps = append(deps, Dependency{
+			ImportPath: dep.ImportPath,
+			Revision:   dep.Rev,
+			Direct:     true,
+		})
+	}
+
+	return deps, nil
+}
@@ -0,0 +1,36 @@
+package depparse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGodeps(t *testing.T) {
+	const content = `{
+		"Deps": [
+			{"ImportPath": "github.com/pkg/errors", "Rev": "614d223910a179a466c1767a985424175c39b465"}
+		]
+	}`
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "Godeps.json")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	deps, err := parseGodeps(path)
+	if err != nil {
+		t.Fatalf("parseGodeps: %v", err)
+	}
+
+	want := []Dependency{{
+		ImportPath: "github.com/pkg/errors",
+		Revision:   "614d223910a179a466c1767a985424175c39b465",
+		Direct:     true,
+	}}
+
+	if len(deps) != len(want) || deps[0] != want[0] {
+		t.Errorf("parseGodeps = %+v, want %+v", deps, want)
+	}
+}
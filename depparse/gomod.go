@@ -0,0 +1,45 @@
+package depparse
+
+import (
+	"io/ioutil"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// parseGoMod reads a go.mod's require directives via golang.org/x/mod, so
+// replaced modules resolve to the module actually being built rather than
+// the replaced one, and indirect requires are labeled as such instead of
+// being indistinguishable from direct ones.
+func parseGoMod(path string) ([]Dependency, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := modfile.Parse(path, data, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	replacements := make(map[string]module.Version, len(file.Replace))
+	for _, replace := range file.Replace {
+		replacements[replace.Old.Path] = replace.New
+	}
+
+	deps := make([]Dependency, 0, len(file.Require))
+	for _, require := range file.Require {
+		mod := require.Mod
+		if replacement, ok := replacements[mod.Path]; ok {
+			mod = replacement
+		}
+
+		deps = append(deps, Dependency{
+			ImportPath: mod.Path,
+			Version:    mod.Version,
+			Direct:     !require.Indirect,
+		})
+	}
+
+	return deps, nil
+}
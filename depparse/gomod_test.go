@@ -0,0 +1,52 @@
+package depparse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGoModDirectAndReplace(t *testing.T) {
+	const content = `module example.com/foo
+
+go 1.21
+
+require (
+	github.com/pkg/errors v0.9.1
+	golang.org/x/mod v0.14.0 // indirect
+)
+
+replace golang.org/x/mod => golang.org/x/mod v0.15.0
+`
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "go.mod")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	deps, err := parseGoMod(path)
+	if err != nil {
+		t.Fatalf("parseGoMod: %v", err)
+	}
+
+	want := map[string]Dependency{
+		"github.com/pkg/errors": {ImportPath: "github.com/pkg/errors", Version: "v0.9.1", Direct: true},
+		"golang.org/x/mod":      {ImportPath: "golang.org/x/mod", Version: "v0.15.0", Direct: false},
+	}
+
+	if len(deps) != len(want) {
+		t.Fatalf("got %d deps, want %d: %+v", len(deps), len(want), deps)
+	}
+
+	for _, dep := range deps {
+		expected, ok := want[dep.ImportPath]
+		if !ok {
+			t.Errorf("unexpected dependency %q", dep.ImportPath)
+			continue
+		}
+		if dep != expected {
+			t.Errorf("dependency %q = %+v, want %+v", dep.ImportPath, dep, expected)
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package depparse
+
+import (
+	"bufio"
+	"os"
+	"strings"
+)
+
+// parseGoSum reads a go.sum, deduping the module@version pair each module
+// normally appears as two lines for (the module zip hash and its go.mod
+// hash).
+func parseGoSum(path string) ([]Dependency, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	seen := make(map[string]bool)
+	var deps []Dependency
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 {
+			continue
+		}
+
+		importPath := fields[0]
+		version := strings.TrimSuffix(fields[1], "/go.mod")
+
+		key := importPath + "@" + version
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		deps = append(deps, Dependency{ImportPath: importPath, Version: version})
+	}
+
+	return deps, scanner.Err()
+}
@@ -0,0 +1,37 @@
+package depparse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseGoSumDedupes(t *testing.T) {
+	const content = `github.com/pkg/errors v0.9.1 h1:FEBLx1zS214owpjy7qsBeixbURkuhQAwrK5UwLGTwt4=
+github.com/pkg/errors v0.9.1/go.mod h1:bwawxfHBFNV+L2hUp1rHADufV3IMtnDRdf1r5NINEl0=
+golang.org/x/mod v0.14.0 h1:dGoOF9QVLYng8IHTm7BAyWqCqSheQ5pYWGhzW00YJr0=
+golang.org/x/mod v0.14.0/go.mod h1:hTbmBsO62+eylJbnUtE2MGJUyE7QWk4xUqPFrRgJ+7c=
+`
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "go.sum")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	deps, err := parseGoSum(path)
+	if err != nil {
+		t.Fatalf("parseGoSum: %v", err)
+	}
+
+	if len(deps) != 2 {
+		t.Fatalf("got %d deps, want 2: %+v", len(deps), deps)
+	}
+
+	if deps[0].ImportPath != "github.com/pkg/errors" || deps[0].Version != "v0.9.1" {
+		t.Errorf("deps[0] = %+v, want github.com/pkg/errors@v0.9.1", deps[0])
+	}
+	if deps[1].ImportPath != "golang.org/x/mod" || deps[1].Version != "v0.14.0" {
+		t.Errorf("deps[1] = %+v, want golang.org/x/mod@v0.14.0", deps[1])
+	}
+}
@@ -0,0 +1,40 @@
+package depparse
+
+import (
+	"bufio"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// vendorModuleHeader matches a vendor/modules.txt module header line, e.g.
+// "# github.com/pkg/errors v0.9.1".
+var vendorModuleHeader = regexp.MustCompile(`^# (\S+) (\S+)`)
+
+// parseVendorModules reads a vendor/modules.txt, treating each "## explicit"
+// marker as flagging the preceding module header as a direct dependency.
+func parseVendorModules(path string) ([]Dependency, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var deps []Dependency
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if match := vendorModuleHeader.FindStringSubmatch(line); match != nil {
+			deps = append(deps, Dependency{ImportPath: match[1], Version: match[2]})
+			continue
+		}
+
+		if len(deps) > 0 && strings.TrimSpace(line) == "## explicit" {
+			deps[len(deps)-1].Direct = true
+		}
+	}
+
+	return deps, scanner.Err()
+}
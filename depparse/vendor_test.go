@@ -0,0 +1,41 @@
+package depparse
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestParseVendorModulesExplicitMarksDirect(t *testing.T) {
+	const content = `# github.com/pkg/errors v0.9.1
+## explicit
+github.com/pkg/errors
+# golang.org/x/mod v0.14.0
+golang.org/x/mod/modfile
+`
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "modules.txt")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("writing fixture: %v", err)
+	}
+
+	deps, err := parseVendorModules(path)
+	if err != nil {
+		t.Fatalf("parseVendorModules: %v", err)
+	}
+
+	want := []Dependency{
+		{ImportPath: "github.com/pkg/errors", Version: "v0.9.1", Direct: true},
+		{ImportPath: "golang.org/x/mod", Version: "v0.14.0", Direct: false},
+	}
+
+	if len(deps) != len(want) {
+		t.Fatalf("got %d deps, want %d: %+v", len(deps), len(want), deps)
+	}
+	for i, dep := range deps {
+		if dep != want[i] {
+			t.Errorf("deps[%d] = %+v, want %+v", i, dep, want[i])
+		}
+	}
+}
@@ -0,0 +1,34 @@
+// Package licensefile locates a license file inside a directory, shared by
+// every part of gh-licenses that scans a tree on disk: the local classifier
+// (cloned dependencies, vendored trees) and LocalClient (shallow git clones).
+package licensefile
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Names are the candidate license file names to look for, in priority order.
+var Names = []string{"LICENSE", "LICENSE.md", "LICENSE.txt", "COPYING", "LICENCE"}
+
+// Find returns the path to the first file in dir matching Names, or "" if
+// none exist.
+func Find(dir string) (string, error) {
+	for _, name := range Names {
+		candidate := filepath.Join(dir, name)
+
+		fi, err := os.Stat(candidate)
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return "", err
+		}
+
+		if !fi.IsDir() {
+			return candidate, nil
+		}
+	}
+
+	return "", nil
+}
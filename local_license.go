@@ -0,0 +1,86 @@
+package main
+
+import (
+	"io/ioutil"
+	"sync"
+
+	classifier "github.com/google/licenseclassifier/v2"
+	"github.com/google/licenseclassifier/v2/assets"
+
+	"github.com/joshmsamuels/gh-licenses/licensefile"
+)
+
+var (
+	defaultClassifierOnce sync.Once
+	defaultClassifier     *classifier.Classifier
+	defaultClassifierErr  error
+)
+
+// licenseClassifier lazily builds the classifier loaded with
+// google/licenseclassifier's bundled corpus. Building it walks and indexes
+// every license in the corpus, so we only do it once per process rather than
+// once per file classified.
+func licenseClassifier() (*classifier.Classifier, error) {
+	defaultClassifierOnce.Do(func() {
+		defaultClassifier, defaultClassifierErr = assets.DefaultClassifier()
+	})
+
+	return defaultClassifier, defaultClassifierErr
+}
+
+// classifyLocalLicense scans dir for a LICENSE/COPYING/LICENCE file and runs
+// it through google/licenseclassifier's bundled corpus, returning the
+// best-matching SPDX identifier and the classifier's confidence in that
+// match. The returned bool is false when no candidate license file was found.
+func classifyLocalLicense(dir string) (License, bool, error) {
+	path, err := licensefile.Find(dir)
+	if err != nil {
+		return License{}, false, err
+	}
+	if path == "" {
+		return License{}, false, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return License{}, false, err
+	}
+
+	return classifyLicenseContent(content)
+}
+
+// classifyLicenseContent runs raw license file text through
+// google/licenseclassifier's bundled corpus, returning the best-matching
+// SPDX identifier and the classifier's confidence in that match. This is
+// shared by the local directory scan above and by RepoClients (GitLab,
+// Bitbucket) that can only return raw file content rather than an
+// already-identified SPDX ID. The returned bool is false when the
+// classifier's top match doesn't clear localConfidenceThreshold.
+func classifyLicenseContent(content []byte) (License, bool, error) {
+	c, err := licenseClassifier()
+	if err != nil {
+		return License{}, false, err
+	}
+
+	results := c.Match(c.Normalize(content))
+	if len(results.Matches) == 0 {
+		return License{}, false, nil
+	}
+
+	best := results.Matches[0]
+	for _, match := range results.Matches[1:] {
+		if match.Confidence > best.Confidence {
+			best = match
+		}
+	}
+
+	if best.Confidence < localConfidenceThreshold {
+		return License{}, false, nil
+	}
+
+	return License{
+		SPDXID:     best.Name,
+		Confidence: best.Confidence,
+		Source:     "local",
+	}, true, nil
+}
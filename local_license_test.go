@@ -0,0 +1,55 @@
+package main
+
+import "testing"
+
+func TestClassifyLicenseContentMIT(t *testing.T) {
+	const mit = `MIT License
+
+Copyright (c) 2023 Example
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in
+all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+THE SOFTWARE.
+`
+
+	license, ok, err := classifyLicenseContent([]byte(mit))
+	if err != nil {
+		t.Fatalf("classifyLicenseContent returned an error: %v", err)
+	}
+	if !ok {
+		t.Fatalf("classifyLicenseContent did not find a confident match")
+	}
+	if license.SPDXID != "MIT" {
+		t.Errorf("SPDXID = %q, want %q", license.SPDXID, "MIT")
+	}
+	if license.Confidence < localConfidenceThreshold {
+		t.Errorf("Confidence = %v, want >= %v", license.Confidence, localConfidenceThreshold)
+	}
+	if license.Source != "local" {
+		t.Errorf("Source = %q, want %q", license.Source, "local")
+	}
+}
+
+func TestClassifyLicenseContentNoMatch(t *testing.T) {
+	_, ok, err := classifyLicenseContent([]byte("just some README prose, not a license"))
+	if err != nil {
+		t.Fatalf("classifyLicenseContent returned an error: %v", err)
+	}
+	if ok {
+		t.Errorf("classifyLicenseContent unexpectedly found a confident match in non-license text")
+	}
+}
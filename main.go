@@ -2,56 +2,192 @@ package main
 
 import (
 	"bufio"
-	"encoding/json"
+	"context"
+	"flag"
 	"fmt"
 	"io/ioutil"
-	"net/http"
 	"os"
 	"path/filepath"
-	"regexp"
+	"sync"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/joshmsamuels/gh-licenses/cache"
+	"github.com/joshmsamuels/gh-licenses/depparse"
+	"github.com/joshmsamuels/gh-licenses/policy"
+	"github.com/joshmsamuels/gh-licenses/report"
+	"github.com/joshmsamuels/gh-licenses/repoclient"
 )
 
-const githubAPIURL = "https://api.github.com"
+// localConfidenceThreshold is the minimum licenseclassifier confidence score
+// (0-1) below which getLicenses falls back to the GitHub API instead of
+// trusting a locally-detected license.
+const localConfidenceThreshold = 0.9
 
 type License struct {
 	Key  string `json:"key"`
 	Name string `json:"name"`
 	URL  string `json:"url"`
+
+	// Source records how the license was resolved: "api" for a forge API
+	// lookup, "local" for a scan of a tree on disk. SPDXID is set by either
+	// path when it yields an identifier; Confidence is only set when the
+	// local licenseclassifier produced the SPDXID.
+	SPDXID     string  `json:"spdx_id,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"`
+	Source     string  `json:"source,omitempty"`
 }
 
-type RepoResponse struct {
-	RepoLicense License `json:"license"`
+// RepoRef is a single repo resolved under a License, carrying whether the
+// manifest that produced it marked it as a direct dependency.
+type RepoRef struct {
+	Path   string
+	Direct bool
 }
 
+var (
+	policyFile  = flag.String("policy", "", "path to a YAML/JSON policy file listing allowed/denied/review SPDX IDs")
+	failOn      = flag.String("fail-on", "deny", "minimum violation severity that causes a non-zero exit: deny or review")
+	format      = flag.String("format", "text", "output format: text, json, csv, spdx, or cyclonedx")
+	concurrency = flag.Int("concurrency", 8, "number of concurrent repo license lookups")
+	cacheTTL    = flag.Duration("cache-ttl", 24*time.Hour, "how long a cached license lookup stays valid; 0 disables expiry")
+)
+
 func main() {
-	// Gets all the arguments excuding the program name
-	args := os.Args[1:]
+	flag.Parse()
+	args := flag.Args()
 
 	if len(args) == 0 {
 		fmt.Printf("At least one filename must be supplied as an argument\n")
 		os.Exit(1)
 	}
 
-	var licenses map[License][]string
+	var licenses map[License][]RepoRef
 
 	for _, filename := range args {
 		newLicenses := getLicenses(filename)
 		licenses = mergeMaps(licenses, newLicenses)
 	}
 
-	prettyPrintLicenses(licenses)
+	reporter := report.ForFormat(*format)
+	if reporter == nil {
+		fmt.Printf("Unknown format %q\n", *format)
+		os.Exit(1)
+	}
+
+	if err := reporter.Report(os.Stdout, toReportEntries(licenses)); err != nil {
+		fmt.Printf("Error rendering report. Error was %v\n", err)
+		os.Exit(1)
+	}
+
+	if *policyFile == "" {
+		return
+	}
+
+	if err := enforcePolicy(*policyFile, *failOn, licenses); err != nil {
+		fmt.Printf("Error enforcing policy %s. Error was %v\n", *policyFile, err)
+		os.Exit(1)
+	}
+}
+
+// enforcePolicy loads the policy file, evaluates it against the resolved
+// licenses, prints any violations, and exits non-zero once a violation
+// reaches failOn's severity ("deny" or "review").
+func enforcePolicy(policyFile, failOn string, licenses map[License][]RepoRef) error {
+	pol, err := policy.Load(policyFile)
+	if err != nil {
+		return err
+	}
+
+	violations := pol.Evaluate(toLicenseResults(licenses))
+	if len(violations) == 0 {
+		return nil
+	}
+
+	printViolations(violations)
+
+	if violatesFailOn(violations, failOn) {
+		os.Exit(1)
+	}
+
+	return nil
+}
+
+// toReportEntries flattens the license map into one report.Entry per repo,
+// which is the shape every Reporter renders.
+func toReportEntries(licenses map[License][]RepoRef) []report.Entry {
+	var entries []report.Entry
+
+	for license, repos := range licenses {
+		for _, repo := range repos {
+			entries = append(entries, report.Entry{
+				Repo:   repo.Path,
+				Key:    license.Key,
+				Name:   license.Name,
+				URL:    license.URL,
+				SPDXID: license.SPDXID,
+				Direct: repo.Direct,
+			})
+		}
+	}
+
+	return entries
 }
 
-func getLicenses(filename string) map[License][]string {
+func toLicenseResults(licenses map[License][]RepoRef) []policy.LicenseResult {
+	var results []policy.LicenseResult
+
+	for license, repos := range licenses {
+		for _, repo := range repos {
+			results = append(results, policy.LicenseResult{Repo: repo.Path, SPDXID: license.SPDXID})
+		}
+	}
+
+	return results
+}
+
+func violatesFailOn(violations []policy.Violation, failOn string) bool {
+	for _, v := range violations {
+		if failOn == "review" {
+			return true
+		}
+
+		if failOn == "deny" && v.Decision == policy.DecisionDeny {
+			return true
+		}
+	}
+
+	return false
+}
+
+func printViolations(violations []policy.Violation) {
+	fmt.Println("Policy violations:")
+
+	for _, v := range violations {
+		fmt.Printf("  %s: %s is %s\n", v.Repo, v.SPDXID, v.Decision)
+	}
+}
+
+func getLicenses(filename string) map[License][]RepoRef {
 	isDirectory, err := isDir(filename)
 	if err != nil {
 		fmt.Printf("Error checking if %s is a directory. Error was %v\n", filename, err)
 		return nil
 	}
 
-	var licenses map[License][]string
+	var licenses map[License][]RepoRef
 
 	if isDirectory {
+		// A directory may itself be a cloned dependency or vendored tree, in
+		// which case we can resolve its license locally without touching the
+		// GitHub API or its rate limit.
+		if license, ok, err := classifyLocalLicense(filename); err != nil {
+			fmt.Printf("Error classifying local license in %s. Error was %v\n", filename, err)
+		} else if ok && license.Confidence >= localConfidenceThreshold {
+			return map[License][]RepoRef{license: {{Path: filename}}}
+		}
+
 		newLicenses := getLicensesFromDir(filename)
 		licenses = mergeMaps(licenses, newLicenses)
 	} else {
@@ -62,147 +198,261 @@ func getLicenses(filename string) map[License][]string {
 	return licenses
 }
 
-func getLicensesFromFile(filename string) map[License][]string {
-	licenses := make(map[License][]string)
+func getLicensesFromFile(filename string) map[License][]RepoRef {
+	deps, err := depparse.ParseFile(filename)
+	if err != nil {
+		fmt.Printf("Error parsing %s. Error was %v\n", filename, err)
+		return nil
+	}
+
+	if deps != nil {
+		return fetchLicensesForDeps(deps)
+	}
+
+	// filename isn't a manifest depparse recognizes; fall back to scanning
+	// it as free-form text for repo references.
+	return getLicensesFromLines(filename)
+}
 
+// getLicensesFromLines scans filename line by line for repo references,
+// the original behavior kept for files depparse doesn't recognize as a
+// dependency manifest.
+func getLicensesFromLines(filename string) map[License][]RepoRef {
 	file, err := os.Open(filename)
 	if err != nil {
-		fmt.Printf("Error opening file %s. Error: %v\n", "go.mod", err)
+		fmt.Printf("Error opening file %s. Error: %v\n", filename, err)
+		return nil
 	}
 	defer file.Close()
 
+	var matches []string
+
 	scanner := bufio.NewScanner(file)
 	for scanner.Scan() {
 		line := stripNewline(scanner.Text())
+		matches = append(matches, repoclient.ExtractMatches(line)...)
+	}
 
-		repos, err := getGithubRepos(line)
-		if err != nil {
-			fmt.Printf("Error getting github repos %v\n", err)
-		}
+	return fetchLicensesForMatches(matches)
+}
 
-		for _, repo := range repos {
-			license, err := getGithubLicense(repo)
+// fetchLicensesForDeps resolves each manifest dependency's forge
+// host/owner/repo and license through a bounded worker pool, so resolving a
+// vanity import path (which may hit the network) doesn't serialize ahead of
+// the license lookups themselves.
+func fetchLicensesForDeps(deps []depparse.Dependency) map[License][]RepoRef {
+	licenses := make(map[License][]RepoRef)
+	var mu sync.Mutex
+
+	group, ctx := errgroup.WithContext(context.Background())
+	group.SetLimit(*concurrency)
+
+	for _, dep := range deps {
+		dep := dep
+
+		group.Go(func() error {
+			resolved, err := repoclient.ResolveImportPath(ctx, dep.ImportPath)
 			if err != nil {
-				fmt.Printf("Error getting license info %v\n", err)
+				fmt.Printf("Error resolving import path %s. Error was %v\n", dep.ImportPath, err)
+				return nil
 			}
 
-			licenses[license] = append(licenses[license], repo)
-		}
+			resolved.Version = dep.Version
+			resolved.Revision = dep.Revision
+			resolved.Direct = dep.Direct
+
+			addLicenseForRepo(ctx, resolved, licenses, &mu)
+			return nil
+		})
 	}
 
+	_ = group.Wait()
+
 	return licenses
 }
 
-func getLicensesFromDir(dir string) map[License][]string {
-	files, err := ioutil.ReadDir(dir)
-	if err != nil {
-		fmt.Printf("Error reading the directory %s. Error was %v\n", dir, err)
-		return nil
-	}
+// fetchLicensesForMatches resolves each free-form text match's forge
+// host/owner/repo and license through the same bounded worker pool as
+// fetchLicensesForDeps.
+func fetchLicensesForMatches(matches []string) map[License][]RepoRef {
+	licenses := make(map[License][]RepoRef)
+	var mu sync.Mutex
 
-	var licenses map[License][]string
+	group, ctx := errgroup.WithContext(context.Background())
+	group.SetLimit(*concurrency)
 
-	for _, file := range files {
-		newLicenses := getLicenses(filepath.Join(dir, file.Name()))
-		licenses = mergeMaps(licenses, newLicenses)
+	for _, match := range matches {
+		match := match
+
+		group.Go(func() error {
+			resolved, err := repoclient.ResolveMatch(ctx, match)
+			if err != nil {
+				fmt.Printf("Error resolving %s. Error was %v\n", match, err)
+				return nil
+			}
+
+			addLicenseForRepo(ctx, resolved, licenses, &mu)
+			return nil
+		})
 	}
 
+	_ = group.Wait()
+
 	return licenses
 }
 
-func isDir(filename string) (bool, error) {
-	fi, err := os.Stat(filename)
+// addLicenseForRepo resolves repo's license (via the cache, falling back to
+// its RepoClient) and records it under licenses, guarded by mu since callers
+// run this concurrently from a worker pool.
+func addLicenseForRepo(ctx context.Context, repo repoclient.Detected, licenses map[License][]RepoRef, mu *sync.Mutex) {
+	ref := RepoRef{Path: repoLabel(repo), Direct: repo.Direct}
+
+	license, err := getLicenseForCached(ctx, repo)
 	if err != nil {
-		return false, err
+		fmt.Printf("Error getting license info %v\n", err)
+		return
 	}
 
-	return fi.IsDir(), nil
+	mu.Lock()
+	licenses[license] = appendUniqueRepoRef(licenses[license], ref)
+	mu.Unlock()
 }
 
-func getGithubLicense(repo string) (License, error) {
-	ownerProj := repo[len("github.com/"):]
-
-	client := &http.Client{}
-
-	req, err := http.NewRequest("GET", fmt.Sprintf("%s/repos/%s", githubAPIURL, ownerProj), nil)
+// repoLabel renders repo as "host/owner/repo", appending the resolved
+// version or revision when depparse was able to determine one.
+func repoLabel(repo repoclient.Detected) string {
+	label := fmt.Sprintf("%s/%s/%s", repo.Host, repo.Owner, repo.Repo)
+
+	switch {
+	case repo.Version != "":
+		return label + "@" + repo.Version
+	case repo.Revision != "":
+		return label + "@" + repo.Revision
+	default:
+		return label
+	}
+}
 
-	// To increase the rate limit from 60-5000 (as of the time of this comment),
-	// GitHub requires an auth token. For a mix of security and ease of use
-	// I decided to use an environment variable for the token.
-	// To generate a new token go to https://github.com/settings/tokens.
-	if authToken := os.Getenv("GITHUB_AUTH_TOKEN"); authToken != "" {
-		req.Header.Set("Authorization", "token "+authToken)
+// getLicenseForCached serves repo's license from the on-disk cache when a
+// fresh entry exists, and otherwise resolves it via getLicenseFor and caches
+// the result.
+func getLicenseForCached(ctx context.Context, repo repoclient.Detected) (License, error) {
+	if entry, ok := cache.Get(repo.Owner, repo.Repo, *cacheTTL); ok {
+		return License{
+			Key:        entry.Key,
+			Name:       entry.Name,
+			URL:        entry.URL,
+			SPDXID:     entry.SPDXID,
+			Confidence: entry.Confidence,
+			Source:     entry.Source,
+		}, nil
 	}
 
-	resp, err := client.Do(req)
+	license, err := getLicenseFor(ctx, repo)
 	if err != nil {
 		return License{}, err
 	}
 
-	defer resp.Body.Close()
+	if err := cache.Set(repo.Owner, repo.Repo, cache.Entry{
+		Key:        license.Key,
+		Name:       license.Name,
+		URL:        license.URL,
+		SPDXID:     license.SPDXID,
+		Confidence: license.Confidence,
+		Source:     license.Source,
+		FetchedAt:  time.Now(),
+	}); err != nil {
+		fmt.Printf("Error caching license for %s/%s. Error was %v\n", repo.Owner, repo.Repo, err)
+	}
 
-	// TODO: Handle error codes (e.g 400, 403, 404, 500, etc)
+	return license, nil
+}
 
-	data, err := ioutil.ReadAll(resp.Body)
+// getLicenseFor looks up the RepoClient for repo's host and resolves its
+// license. Hosts that only return raw file content (GitLab, Bitbucket) are
+// run through the same local classifier used for cloned trees.
+func getLicenseFor(ctx context.Context, repo repoclient.Detected) (License, error) {
+	client := repoclient.ForHost(repo.Host)
+	if client == nil {
+		client = repoclient.NewLocalClient()
+	}
 
-	var repoResp RepoResponse
-	err = json.Unmarshal(data, &repoResp)
+	resolved, err := client.GetLicense(ctx, repo.Host, repo.Owner, repo.Repo)
 	if err != nil {
 		return License{}, err
 	}
 
-	return repoResp.RepoLicense, nil
-}
+	license := License{
+		Key:        resolved.Key,
+		Name:       resolved.Name,
+		URL:        resolved.URL,
+		SPDXID:     resolved.SPDXID,
+		Confidence: resolved.Confidence,
+		Source:     resolved.Source,
+	}
 
-func stripNewline(text string) string {
-	if len(text) > 0 && text[len(text)-1] == '\n' {
-		return text[:len(text)-1]
+	if license.SPDXID == "" && len(resolved.RawContent) > 0 {
+		if classified, ok, err := classifyLicenseContent(resolved.RawContent); err == nil && ok {
+			license.SPDXID = classified.SPDXID
+			license.Confidence = classified.Confidence
+		}
 	}
 
-	return text
+	return license, nil
 }
 
-func getGithubRepos(text string) ([]string, error) {
-	regex, err := regexp.Compile(`github\.com[^\s]+`)
+func getLicensesFromDir(dir string) map[License][]RepoRef {
+	files, err := ioutil.ReadDir(dir)
 	if err != nil {
-		return nil, err
+		fmt.Printf("Error reading the directory %s. Error was %v\n", dir, err)
+		return nil
 	}
 
-	return regex.FindAllString(text, -1), nil
-}
+	licenses := make(map[License][]RepoRef)
+	var mu sync.Mutex
 
-func (license *License) print() {
-	fmt.Printf("Name: %s | Key: %s | URL: %s\n", license.Name, license.Key, license.URL)
-}
+	group := new(errgroup.Group)
+	group.SetLimit(*concurrency)
 
-func prettyPrintLicenses(licenses map[License][]string) {
-	for license, repos := range licenses {
-		license.print()
-		printArr("Repos", repos)
+	for _, file := range files {
+		file := file
+
+		group.Go(func() error {
+			newLicenses := getLicenses(filepath.Join(dir, file.Name()))
+
+			mu.Lock()
+			licenses = mergeMaps(licenses, newLicenses)
+			mu.Unlock()
 
-		fmt.Println()
+			return nil
+		})
 	}
-}
 
-func printArr(prompt string, arr []string) {
-	fmt.Printf("%s: ", prompt)
-	arrLen := len(arr)
+	_ = group.Wait()
 
-	if arrLen == 0 {
-		fmt.Println()
-		return
+	return licenses
+}
+
+func isDir(filename string) (bool, error) {
+	fi, err := os.Stat(filename)
+	if err != nil {
+		return false, err
 	}
 
-	for _, a := range arr[:arrLen-1] {
-		fmt.Printf("%s, ", a)
+	return fi.IsDir(), nil
+}
+
+func stripNewline(text string) string {
+	if len(text) > 0 && text[len(text)-1] == '\n' {
+		return text[:len(text)-1]
 	}
 
-	fmt.Printf("%s\n", arr[arrLen-1])
+	return text
 }
 
-func mergeMaps(map1 map[License][]string, map2 map[License][]string) map[License][]string {
+func mergeMaps(map1 map[License][]RepoRef, map2 map[License][]RepoRef) map[License][]RepoRef {
 	if map1 == nil && map2 == nil {
-		return make(map[License][]string)
+		return make(map[License][]RepoRef)
 	}
 
 	if map1 == nil {
@@ -213,7 +463,7 @@ func mergeMaps(map1 map[License][]string, map2 map[License][]string) map[License
 		return map1
 	}
 
-	merged := make(map[License][]string)
+	merged := make(map[License][]RepoRef)
 
 	// copys all the values from map1 into the new map
 	for key, val := range map1 {
@@ -226,32 +476,58 @@ func mergeMaps(map1 map[License][]string, map2 map[License][]string) map[License
 			continue
 		}
 
-		merged[key] = appendUnique(merged[key], map2[key]...)
+		merged[key] = appendUniqueRepoRef(merged[key], map2[key]...)
 	}
 
-	return merged
+	return dedupeBySPDXID(merged)
 }
 
-func appendUnique(currentStrings []string, newStrings ...string) []string {
-	var newArrStrings []string
+// dedupeBySPDXID collapses entries that share a SPDX ID but were resolved by
+// different sources (e.g. the local classifier and the GitHub API both
+// identifying the same dependency as "MIT"). The local result wins because
+// it doesn't depend on network access or rate limits.
+func dedupeBySPDXID(licenses map[License][]RepoRef) map[License][]RepoRef {
+	canonical := make(map[string]License)
+	repos := make(map[string][]RepoRef)
+
+	for license, reposForLicense := range licenses {
+		groupKey := license.SPDXID
+		if groupKey == "" {
+			groupKey = license.Key + "|" + license.Name
+		}
 
-	for _, currentString := range currentStrings {
-		newArrStrings = append(newArrStrings, currentString)
+		existing, ok := canonical[groupKey]
+		if !ok || (existing.Source == "api" && license.Source == "local") {
+			canonical[groupKey] = license
+		}
+
+		repos[groupKey] = appendUniqueRepoRef(repos[groupKey], reposForLicense...)
+	}
+
+	deduped := make(map[License][]RepoRef, len(canonical))
+	for groupKey, license := range canonical {
+		deduped[license] = repos[groupKey]
 	}
 
-	for _, newString := range newStrings {
+	return deduped
+}
+
+func appendUniqueRepoRef(current []RepoRef, new ...RepoRef) []RepoRef {
+	merged := append([]RepoRef{}, current...)
+
+	for _, ref := range new {
 		found := false
-		for _, currentString := range currentStrings {
-			if newString == currentString {
+		for _, existing := range current {
+			if ref.Path == existing.Path {
 				found = true
 				break
 			}
 		}
 
 		if !found {
-			newArrStrings = append(newArrStrings, newString)
+			merged = append(merged, ref)
 		}
 	}
 
-	return newArrStrings
+	return merged
 }
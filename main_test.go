@@ -0,0 +1,187 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/joshmsamuels/gh-licenses/policy"
+	"github.com/joshmsamuels/gh-licenses/repoclient"
+)
+
+func TestMergeMapsUnion(t *testing.T) {
+	mit := License{Key: "mit", SPDXID: "MIT"}
+	apache := License{Key: "apache-2.0", SPDXID: "Apache-2.0"}
+
+	map1 := map[License][]RepoRef{mit: {{Path: "github.com/a/b"}}}
+	map2 := map[License][]RepoRef{apache: {{Path: "github.com/c/d"}}}
+
+	merged := mergeMaps(map1, map2)
+
+	if len(merged) != 2 {
+		t.Fatalf("got %d licenses, want 2: %+v", len(merged), merged)
+	}
+	if len(merged[mit]) != 1 || merged[mit][0].Path != "github.com/a/b" {
+		t.Errorf("merged[mit] = %+v, want [{github.com/a/b false}]", merged[mit])
+	}
+	if len(merged[apache]) != 1 || merged[apache][0].Path != "github.com/c/d" {
+		t.Errorf("merged[apache] = %+v, want [{github.com/c/d false}]", merged[apache])
+	}
+}
+
+func TestMergeMapsHandlesNils(t *testing.T) {
+	mit := License{Key: "mit", SPDXID: "MIT"}
+	populated := map[License][]RepoRef{mit: {{Path: "github.com/a/b"}}}
+
+	if got := mergeMaps(nil, nil); len(got) != 0 {
+		t.Errorf("mergeMaps(nil, nil) = %+v, want empty", got)
+	}
+	if got := mergeMaps(nil, populated); len(got[mit]) != 1 {
+		t.Errorf("mergeMaps(nil, populated) = %+v, want populated", got)
+	}
+	if got := mergeMaps(populated, nil); len(got[mit]) != 1 {
+		t.Errorf("mergeMaps(populated, nil) = %+v, want populated", got)
+	}
+}
+
+func TestMergeMapsCombinesSameLicenseRepos(t *testing.T) {
+	mit := License{Key: "mit", SPDXID: "MIT"}
+	map1 := map[License][]RepoRef{mit: {{Path: "github.com/a/b"}}}
+	map2 := map[License][]RepoRef{mit: {{Path: "github.com/a/b"}, {Path: "github.com/c/d"}}}
+
+	merged := mergeMaps(map1, map2)
+
+	if len(merged[mit]) != 2 {
+		t.Fatalf("merged[mit] = %+v, want 2 unique repos", merged[mit])
+	}
+}
+
+func TestDedupeBySPDXIDCollapsesSharedSPDXID(t *testing.T) {
+	apiLicense := License{Key: "mit", Name: "MIT License", URL: "https://api.github.com/licenses/mit", SPDXID: "MIT", Source: "api"}
+	localLicense := License{SPDXID: "MIT", Confidence: 0.97, Source: "local"}
+
+	licenses := map[License][]RepoRef{
+		apiLicense:   {{Path: "github.com/a/b"}},
+		localLicense: {{Path: "github.com/c/d"}},
+	}
+
+	deduped := dedupeBySPDXID(licenses)
+
+	if len(deduped) != 1 {
+		t.Fatalf("got %d licenses, want 1: %+v", len(deduped), deduped)
+	}
+
+	for license, repos := range deduped {
+		if license.Source != "local" {
+			t.Errorf("canonical license = %+v, want the local result to win", license)
+		}
+		if len(repos) != 2 {
+			t.Errorf("repos = %+v, want both repos carried over", repos)
+		}
+	}
+}
+
+func TestDedupeBySPDXIDGroupsByKeyAndNameWhenSPDXIDMissing(t *testing.T) {
+	custom := License{Key: "custom", Name: "Some Custom License"}
+	other := License{Key: "other", Name: "Some Other License"}
+
+	licenses := map[License][]RepoRef{
+		custom: {{Path: "github.com/a/b"}},
+		other:  {{Path: "github.com/c/d"}},
+	}
+
+	deduped := dedupeBySPDXID(licenses)
+
+	if len(deduped) != 2 {
+		t.Fatalf("got %d licenses, want 2 (no SPDX ID in common): %+v", len(deduped), deduped)
+	}
+}
+
+func TestRepoLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		repo repoclient.Detected
+		want string
+	}{
+		{"no version or revision", repoclient.Detected{Host: "github.com", Owner: "a", Repo: "b"}, "github.com/a/b"},
+		{"version", repoclient.Detected{Host: "github.com", Owner: "a", Repo: "b", Version: "v1.2.3"}, "github.com/a/b@v1.2.3"},
+		{"revision wins when no version", repoclient.Detected{Host: "github.com", Owner: "a", Repo: "b", Revision: "abc123"}, "github.com/a/b@abc123"},
+		{"version wins over revision", repoclient.Detected{Host: "github.com", Owner: "a", Repo: "b", Version: "v1.2.3", Revision: "abc123"}, "github.com/a/b@v1.2.3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := repoLabel(tt.repo); got != tt.want {
+				t.Errorf("repoLabel(%+v) = %q, want %q", tt.repo, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAppendUniqueRepoRefSkipsDuplicatePaths(t *testing.T) {
+	current := []RepoRef{{Path: "github.com/a/b", Direct: true}}
+
+	got := appendUniqueRepoRef(current, RepoRef{Path: "github.com/a/b"}, RepoRef{Path: "github.com/c/d"})
+
+	if len(got) != 2 {
+		t.Fatalf("got %+v, want 2 entries", got)
+	}
+	if got[0].Path != "github.com/a/b" || !got[0].Direct {
+		t.Errorf("existing entry was modified: %+v", got[0])
+	}
+	if got[1].Path != "github.com/c/d" {
+		t.Errorf("new entry missing: %+v", got)
+	}
+}
+
+func TestToReportEntries(t *testing.T) {
+	mit := License{Key: "mit", Name: "MIT License", URL: "https://example.com/mit", SPDXID: "MIT"}
+	licenses := map[License][]RepoRef{
+		mit: {{Path: "github.com/a/b", Direct: true}, {Path: "github.com/c/d"}},
+	}
+
+	entries := toReportEntries(licenses)
+
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2: %+v", len(entries), entries)
+	}
+
+	byRepo := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		if e.SPDXID != "MIT" || e.Key != "mit" {
+			t.Errorf("entry %+v missing license fields", e)
+		}
+		byRepo[e.Repo] = e.Direct
+	}
+
+	if !byRepo["github.com/a/b"] {
+		t.Errorf("github.com/a/b should be marked Direct")
+	}
+	if byRepo["github.com/c/d"] {
+		t.Errorf("github.com/c/d should not be marked Direct")
+	}
+}
+
+func TestViolatesFailOn(t *testing.T) {
+	denyViolation := []policy.Violation{{Repo: "github.com/a/b", SPDXID: "GPL-3.0", Decision: policy.DecisionDeny}}
+	reviewViolation := []policy.Violation{{Repo: "github.com/a/b", SPDXID: "WTFPL", Decision: policy.DecisionReview}}
+
+	tests := []struct {
+		name       string
+		violations []policy.Violation
+		failOn     string
+		want       bool
+	}{
+		{"deny violation fails on deny", denyViolation, "deny", true},
+		{"review violation does not fail on deny", reviewViolation, "deny", false},
+		{"review violation fails on review", reviewViolation, "review", true},
+		{"deny violation fails on review", denyViolation, "review", true},
+		{"no violations never fails", nil, "review", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := violatesFailOn(tt.violations, tt.failOn); got != tt.want {
+				t.Errorf("violatesFailOn(%+v, %q) = %v, want %v", tt.violations, tt.failOn, got, tt.want)
+			}
+		})
+	}
+}
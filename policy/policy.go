@@ -0,0 +1,111 @@
+// Package policy evaluates the licenses gh-licenses resolves for a set of
+// repos against an allow/deny/review policy, so the tool can be used as a
+// CI gate rather than just a pretty-printer.
+package policy
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Decision is the action a policy rule assigns to a license.
+type Decision string
+
+const (
+	DecisionAllow  Decision = "allow"
+	DecisionDeny   Decision = "deny"
+	DecisionReview Decision = "review"
+)
+
+// Policy describes which SPDX license identifiers are allowed, denied, or
+// flagged for manual review, along with per-repo overrides of those
+// defaults (e.g. a denied license that's acceptable for one specific repo).
+type Policy struct {
+	Allowed   []string            `yaml:"allowed" json:"allowed"`
+	Denied    []string            `yaml:"denied" json:"denied"`
+	Review    []string            `yaml:"review" json:"review"`
+	Overrides map[string]Decision `yaml:"overrides" json:"overrides"`
+}
+
+// LicenseResult is the subset of a resolved repo/license pairing that the
+// policy engine needs in order to evaluate a rule.
+type LicenseResult struct {
+	Repo   string
+	SPDXID string
+}
+
+// Violation records a single repo/license pair that tripped a policy rule.
+type Violation struct {
+	Repo     string
+	SPDXID   string
+	Decision Decision
+}
+
+// Load reads and parses a policy file. JSON is a valid subset of YAML, so
+// this also accepts plain JSON policy files.
+func Load(path string) (*Policy, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("parsing policy file %s: %w", path, err)
+	}
+
+	return &p, nil
+}
+
+// Evaluate applies the policy to a set of resolved licenses and returns one
+// Violation per repo/license pair whose decision is deny or review.
+func (p *Policy) Evaluate(results []LicenseResult) []Violation {
+	var violations []Violation
+
+	for _, result := range results {
+		decision := p.decide(result)
+		if decision == DecisionDeny || decision == DecisionReview {
+			violations = append(violations, Violation{
+				Repo:     result.Repo,
+				SPDXID:   result.SPDXID,
+				Decision: decision,
+			})
+		}
+	}
+
+	return violations
+}
+
+func (p *Policy) decide(result LicenseResult) Decision {
+	if override, ok := p.Overrides[result.Repo]; ok {
+		return override
+	}
+
+	if contains(p.Denied, result.SPDXID) {
+		return DecisionDeny
+	}
+
+	if contains(p.Review, result.SPDXID) {
+		return DecisionReview
+	}
+
+	if contains(p.Allowed, result.SPDXID) {
+		return DecisionAllow
+	}
+
+	// Anything not explicitly classified defaults to review so unknown
+	// licenses don't silently slip through CI.
+	return DecisionReview
+}
+
+func contains(list []string, target string) bool {
+	for _, item := range list {
+		if item == target {
+			return true
+		}
+	}
+
+	return false
+}
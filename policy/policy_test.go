@@ -0,0 +1,100 @@
+package policy
+
+import (
+	"os"
+	"testing"
+)
+
+func TestDecide(t *testing.T) {
+	p := &Policy{
+		Allowed:   []string{"MIT", "Apache-2.0"},
+		Denied:    []string{"GPL-3.0"},
+		Review:    []string{"LGPL-3.0"},
+		Overrides: map[string]Decision{"github.com/special/repo": DecisionAllow},
+	}
+
+	tests := []struct {
+		name   string
+		result LicenseResult
+		want   Decision
+	}{
+		{"allowed", LicenseResult{Repo: "github.com/a/b", SPDXID: "MIT"}, DecisionAllow},
+		{"denied", LicenseResult{Repo: "github.com/a/b", SPDXID: "GPL-3.0"}, DecisionDeny},
+		{"review-listed", LicenseResult{Repo: "github.com/a/b", SPDXID: "LGPL-3.0"}, DecisionReview},
+		{"unknown-defaults-to-review", LicenseResult{Repo: "github.com/a/b", SPDXID: "WTFPL"}, DecisionReview},
+		{"override-wins-over-deny", LicenseResult{Repo: "github.com/special/repo", SPDXID: "GPL-3.0"}, DecisionAllow},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := p.decide(tt.result); got != tt.want {
+				t.Errorf("decide(%+v) = %v, want %v", tt.result, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateOnlyReturnsViolations(t *testing.T) {
+	p := &Policy{
+		Allowed: []string{"MIT"},
+		Denied:  []string{"GPL-3.0"},
+	}
+
+	results := []LicenseResult{
+		{Repo: "github.com/a/allowed", SPDXID: "MIT"},
+		{Repo: "github.com/a/denied", SPDXID: "GPL-3.0"},
+		{Repo: "github.com/a/unknown", SPDXID: "WTFPL"},
+	}
+
+	violations := p.Evaluate(results)
+
+	if len(violations) != 2 {
+		t.Fatalf("got %d violations, want 2: %+v", len(violations), violations)
+	}
+
+	byRepo := make(map[string]Violation, len(violations))
+	for _, v := range violations {
+		byRepo[v.Repo] = v
+	}
+
+	if v, ok := byRepo["github.com/a/denied"]; !ok || v.Decision != DecisionDeny {
+		t.Errorf("denied repo violation = %+v, ok=%v, want Decision=%v", v, ok, DecisionDeny)
+	}
+	if v, ok := byRepo["github.com/a/unknown"]; !ok || v.Decision != DecisionReview {
+		t.Errorf("unknown repo violation = %+v, ok=%v, want Decision=%v", v, ok, DecisionReview)
+	}
+	if _, ok := byRepo["github.com/a/allowed"]; ok {
+		t.Errorf("allowed repo should not produce a violation")
+	}
+}
+
+func TestLoadParsesYAMLAndJSON(t *testing.T) {
+	dir := t.TempDir()
+
+	yamlPath := dir + "/policy.yaml"
+	if err := os.WriteFile(yamlPath, []byte("allowed:\n  - MIT\ndenied:\n  - GPL-3.0\n"), 0o644); err != nil {
+		t.Fatalf("writing yaml fixture: %v", err)
+	}
+
+	p, err := Load(yamlPath)
+	if err != nil {
+		t.Fatalf("Load(yaml): %v", err)
+	}
+	if len(p.Allowed) != 1 || p.Allowed[0] != "MIT" {
+		t.Errorf("Allowed = %v, want [MIT]", p.Allowed)
+	}
+
+	jsonPath := dir + "/policy.json"
+	if err := os.WriteFile(jsonPath, []byte(`{"allowed": ["MIT"], "denied": ["GPL-3.0"]}`), 0o644); err != nil {
+		t.Fatalf("writing json fixture: %v", err)
+	}
+
+	p, err = Load(jsonPath)
+	if err != nil {
+		t.Fatalf("Load(json): %v", err)
+	}
+	if len(p.Denied) != 1 || p.Denied[0] != "GPL-3.0" {
+		t.Errorf("Denied = %v, want [GPL-3.0]", p.Denied)
+	}
+}
+
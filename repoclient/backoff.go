@@ -0,0 +1,78 @@
+package repoclient
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	initialBackoff = 10 * time.Millisecond
+	maxBackoff     = 10 * time.Second
+	maxRetries     = 5
+)
+
+// doWithBackoff issues req via client, retrying 5xx responses and secondary
+// rate-limit 403s with exponential backoff (similar to woodpecker's backoff
+// config), and sleeping until reset instead of erroring when GitHub's
+// primary rate limit is exhausted.
+func doWithBackoff(ctx context.Context, client *http.Client, req *http.Request) (*http.Response, error) {
+	backoff := initialBackoff
+
+	for attempt := 0; ; attempt++ {
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode == http.StatusForbidden && resp.Header.Get("X-RateLimit-Remaining") == "0" {
+			resp.Body.Close()
+
+			if err := sleepUntilReset(ctx, resp.Header.Get("X-RateLimit-Reset")); err != nil {
+				return nil, err
+			}
+
+			continue
+		}
+
+		retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusForbidden
+		if !retryable || attempt >= maxRetries {
+			return resp, nil
+		}
+
+		resp.Body.Close()
+
+		select {
+		case <-time.After(backoff):
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// sleepUntilReset blocks until the Unix timestamp in resetHeader, which is
+// how GitHub reports when a primary rate limit window rolls over.
+func sleepUntilReset(ctx context.Context, resetHeader string) error {
+	resetUnix, err := strconv.ParseInt(resetHeader, 10, 64)
+	if err != nil {
+		return nil
+	}
+
+	wait := time.Until(time.Unix(resetUnix, 0))
+	if wait <= 0 {
+		return nil
+	}
+
+	select {
+	case <-time.After(wait):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,60 @@
+package repoclient
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+)
+
+const bitbucketAPIURL = "https://api.bitbucket.org/2.0"
+
+// BitbucketClient resolves licenses via Bitbucket's source browsing API.
+// Bitbucket has no dedicated license-detection endpoint, so like GitLab we
+// only get raw file content back.
+type BitbucketClient struct {
+	HTTPClient *http.Client
+}
+
+func NewBitbucketClient() *BitbucketClient {
+	return &BitbucketClient{HTTPClient: &http.Client{}}
+}
+
+func (c *BitbucketClient) GetLicense(ctx context.Context, host, owner, repo string) (License, error) {
+	endpoint := fmt.Sprintf("%s/repositories/%s/%s/src/HEAD/LICENSE", bitbucketAPIURL, owner, repo)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return License{}, err
+	}
+
+	if authToken := os.Getenv("BITBUCKET_AUTH_TOKEN"); authToken != "" {
+		req.Header.Set("Authorization", "Bearer "+authToken)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return License{}, err
+	}
+	defer resp.Body.Close()
+
+	// TODO: Handle error codes (e.g 400, 403, 404, 500, etc)
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return License{}, err
+	}
+
+	// Source is "api" like GitHub's client: both resolved this license from
+	// the forge's API rather than a local scan of a cloned tree.
+	return License{
+		URL:        endpoint,
+		Source:     "api",
+		RawContent: content,
+	}, nil
+}
+
+func (c *BitbucketClient) GetRepoMetadata(ctx context.Context, host, owner, repo string) (RepoMetadata, error) {
+	return RepoMetadata{Owner: owner, Repo: repo, Host: "bitbucket.org"}, nil
+}
@@ -0,0 +1,143 @@
+package repoclient
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Detected is a single dependency reference extracted from a line of text,
+// resolved to the forge host that actually holds its code.
+type Detected struct {
+	Host  string // "github.com", "gitlab.com", "bitbucket.org"
+	Owner string
+	Repo  string
+
+	// Version and Revision are only populated when Detected came from a
+	// parsed manifest (go.mod, go.sum, Godeps.json, vendor/modules.txt)
+	// rather than a free-form text scan.
+	Version  string
+	Revision string
+
+	// Direct mirrors depparse.Dependency.Direct: true when the manifest
+	// marked this repo as directly imported rather than pulled in
+	// transitively. Always false for repos detected from free-form text.
+	Direct bool
+}
+
+var (
+	repoPattern    = regexp.MustCompile(`(?:github\.com|gitlab\.com|bitbucket\.org|gopkg\.in)/[a-zA-Z0-9_.\-/]+`)
+	goImportMeta   = regexp.MustCompile(`<meta name="go-import" content="[^"]+ git (https://[^"\s]+)"`)
+	trailingPeriod = regexp.MustCompile(`\.+$`)
+)
+
+// vanityImportTimeout bounds how long a single go-get redirect lookup may
+// take, so one unresponsive vanity-import host can't stall an entire run.
+const vanityImportTimeout = 10 * time.Second
+
+var vanityHTTPClient = &http.Client{Timeout: vanityImportTimeout}
+
+// ExtractMatches scans a line of text (e.g. from a go.mod or go.sum, or a
+// free-form file) for raw dependency references, without resolving any of
+// them - resolution can hit the network (for gopkg.in vanity imports), so
+// callers should fan it out across a worker pool via ResolveMatch rather
+// than resolving inline while scanning.
+func ExtractMatches(text string) []string {
+	matches := repoPattern.FindAllString(text, -1)
+	for i, match := range matches {
+		matches[i] = trailingPeriod.ReplaceAllString(match, "")
+	}
+	return matches
+}
+
+// ResolveMatch resolves a single raw match from ExtractMatches to the
+// RepoClient-compatible host/owner/repo it should be looked up under,
+// following gopkg.in's vanity-import redirect to the GitHub repo it
+// actually points at.
+func ResolveMatch(ctx context.Context, match string) (Detected, error) {
+	host, rest, ok := strings.Cut(match, "/")
+	if !ok {
+		return Detected{}, fmt.Errorf("match %q has no repo path", match)
+	}
+
+	if host != "gopkg.in" {
+		owner, repo, ok := strings.Cut(rest, "/")
+		if !ok {
+			return Detected{}, fmt.Errorf("match %q is missing an owner/repo", match)
+		}
+		repo, _, _ = strings.Cut(repo, "/")
+		return Detected{Host: host, Owner: owner, Repo: repo}, nil
+	}
+
+	return resolveVanityImport(ctx, match)
+}
+
+// resolveVanityImport follows the go-import meta tag convention
+// (see "go help importpath") that gopkg.in relies on, so dependencies
+// importable at gopkg.in/pkg.vN resolve to the GitHub repo that actually
+// hosts the code and its license.
+func resolveVanityImport(ctx context.Context, importPath string) (Detected, error) {
+	ctx, cancel := context.WithTimeout(ctx, vanityImportTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("https://%s?go-get=1", importPath), nil)
+	if err != nil {
+		return Detected{}, err
+	}
+
+	resp, err := vanityHTTPClient.Do(req)
+	if err != nil {
+		return Detected{}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return Detected{}, err
+	}
+
+	match := goImportMeta.FindStringSubmatch(string(body))
+	if match == nil {
+		// Couldn't resolve the redirect; fall back to treating the import
+		// path's own host as the forge so the caller can still attempt a
+		// local clone of it.
+		host, rest, _ := strings.Cut(importPath, "/")
+		owner, repo, _ := strings.Cut(rest, "/")
+		return Detected{Host: host, Owner: owner, Repo: repo}, nil
+	}
+
+	repoURL := strings.TrimPrefix(match[1], "https://")
+	host, rest, _ := strings.Cut(repoURL, "/")
+	owner, repo, _ := strings.Cut(rest, "/")
+
+	return Detected{Host: host, Owner: owner, Repo: repo}, nil
+}
+
+// ResolveImportPath resolves a single dependency import path (e.g. from a
+// go.mod require line or a Godeps.json entry) to the RepoClient-compatible
+// host/owner/repo it should be looked up under. Unlike ExtractMatches, it
+// doesn't scan free-form text for matches - the caller already knows
+// importPath names exactly one module.
+func ResolveImportPath(ctx context.Context, importPath string) (Detected, error) {
+	host, rest, ok := strings.Cut(importPath, "/")
+	if !ok {
+		return Detected{}, fmt.Errorf("import path %q has no repo path", importPath)
+	}
+
+	if host == "github.com" || host == "gitlab.com" || host == "bitbucket.org" {
+		owner, repo, ok := strings.Cut(rest, "/")
+		if !ok {
+			return Detected{}, fmt.Errorf("import path %q is missing an owner/repo", importPath)
+		}
+		// Drop any package or major-version subpath (e.g. "/v2") below the repo.
+		repo, _, _ = strings.Cut(repo, "/")
+
+		return Detected{Host: host, Owner: owner, Repo: repo}, nil
+	}
+
+	return resolveVanityImport(ctx, importPath)
+}
@@ -0,0 +1,62 @@
+package repoclient
+
+import (
+	"context"
+	"testing"
+)
+
+func TestResolveImportPathKnownForges(t *testing.T) {
+	tests := []struct {
+		importPath string
+		want       Detected
+	}{
+		{"github.com/pkg/errors", Detected{Host: "github.com", Owner: "pkg", Repo: "errors"}},
+		{"gitlab.com/gitlab-org/gitlab", Detected{Host: "gitlab.com", Owner: "gitlab-org", Repo: "gitlab"}},
+		{"bitbucket.org/ww/goautoneg", Detected{Host: "bitbucket.org", Owner: "ww", Repo: "goautoneg"}},
+	}
+
+	for _, tt := range tests {
+		got, err := ResolveImportPath(context.Background(), tt.importPath)
+		if err != nil {
+			t.Errorf("ResolveImportPath(%q): unexpected error: %v", tt.importPath, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("ResolveImportPath(%q) = %+v, want %+v", tt.importPath, got, tt.want)
+		}
+	}
+}
+
+func TestResolveImportPathMissingRepo(t *testing.T) {
+	if _, err := ResolveImportPath(context.Background(), "github.com"); err == nil {
+		t.Error("expected an error for an import path with no owner/repo")
+	}
+}
+
+func TestExtractMatches(t *testing.T) {
+	text := "see github.com/pkg/errors. and bitbucket.org/ww/goautoneg, for reference"
+
+	got := ExtractMatches(text)
+	want := []string{"github.com/pkg/errors", "bitbucket.org/ww/goautoneg"}
+
+	if len(got) != len(want) {
+		t.Fatalf("ExtractMatches(%q) = %v, want %v", text, got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("ExtractMatches(%q)[%d] = %q, want %q", text, i, got[i], want[i])
+		}
+	}
+}
+
+func TestResolveMatchKnownForge(t *testing.T) {
+	got, err := ResolveMatch(context.Background(), "github.com/pkg/errors")
+	if err != nil {
+		t.Fatalf("ResolveMatch: unexpected error: %v", err)
+	}
+
+	want := Detected{Host: "github.com", Owner: "pkg", Repo: "errors"}
+	if got != want {
+		t.Errorf("ResolveMatch = %+v, want %+v", got, want)
+	}
+}
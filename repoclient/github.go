@@ -0,0 +1,77 @@
+package repoclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+)
+
+const githubAPIURL = "https://api.github.com"
+
+// GitHubClient resolves licenses via the GitHub REST API. This is the
+// original behavior gh-licenses shipped with, now behind the RepoClient
+// interface.
+type GitHubClient struct {
+	HTTPClient *http.Client
+}
+
+func NewGitHubClient() *GitHubClient {
+	return &GitHubClient{HTTPClient: &http.Client{}}
+}
+
+type githubRepoResponse struct {
+	RepoLicense struct {
+		Key    string `json:"key"`
+		Name   string `json:"name"`
+		URL    string `json:"url"`
+		SPDXID string `json:"spdx_id"`
+	} `json:"license"`
+}
+
+func (c *GitHubClient) GetLicense(ctx context.Context, host, owner, repo string) (License, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", fmt.Sprintf("%s/repos/%s/%s", githubAPIURL, owner, repo), nil)
+	if err != nil {
+		return License{}, err
+	}
+
+	// To increase the rate limit from 60-5000 (as of the time of this
+	// comment), GitHub requires an auth token. For a mix of security and
+	// ease of use we use an environment variable for the token. To generate
+	// a new token go to https://github.com/settings/tokens.
+	if authToken := os.Getenv("GITHUB_AUTH_TOKEN"); authToken != "" {
+		req.Header.Set("Authorization", "token "+authToken)
+	}
+
+	resp, err := doWithBackoff(ctx, c.HTTPClient, req)
+	if err != nil {
+		return License{}, err
+	}
+	defer resp.Body.Close()
+
+	// TODO: Handle remaining error codes (e.g 400, 404)
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return License{}, err
+	}
+
+	var repoResp githubRepoResponse
+	if err := json.Unmarshal(data, &repoResp); err != nil {
+		return License{}, err
+	}
+
+	return License{
+		Key:    repoResp.RepoLicense.Key,
+		Name:   repoResp.RepoLicense.Name,
+		URL:    repoResp.RepoLicense.URL,
+		SPDXID: repoResp.RepoLicense.SPDXID,
+		Source: "api",
+	}, nil
+}
+
+func (c *GitHubClient) GetRepoMetadata(ctx context.Context, host, owner, repo string) (RepoMetadata, error) {
+	return RepoMetadata{Owner: owner, Repo: repo, Host: "github.com"}, nil
+}
@@ -0,0 +1,24 @@
+package repoclient
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGithubRepoResponseUsesSPDXID(t *testing.T) {
+	// GitHub's license.key is a lowercase slug ("mit"); license.spdx_id is the
+	// actual SPDX identifier ("MIT"). GetLicense must use the latter.
+	const body = `{"license":{"key":"mit","name":"MIT License","url":"https://api.github.com/licenses/mit","spdx_id":"MIT"}}`
+
+	var repoResp githubRepoResponse
+	if err := json.Unmarshal([]byte(body), &repoResp); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if repoResp.RepoLicense.SPDXID != "MIT" {
+		t.Errorf("SPDXID = %q, want %q", repoResp.RepoLicense.SPDXID, "MIT")
+	}
+	if repoResp.RepoLicense.Key != "mit" {
+		t.Errorf("Key = %q, want %q", repoResp.RepoLicense.Key, "mit")
+	}
+}
@@ -0,0 +1,65 @@
+package repoclient
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+)
+
+const gitlabAPIURL = "https://gitlab.com/api/v4"
+
+// GitLabClient resolves licenses via GitLab's repository files API, which
+// unlike GitHub's has no license-detection endpoint of its own: it only
+// hands back the raw file content.
+type GitLabClient struct {
+	HTTPClient *http.Client
+}
+
+func NewGitLabClient() *GitLabClient {
+	return &GitLabClient{HTTPClient: &http.Client{}}
+}
+
+func (c *GitLabClient) GetLicense(ctx context.Context, host, owner, repo string) (License, error) {
+	projectID := url.QueryEscape(owner + "/" + repo)
+	endpoint := fmt.Sprintf("%s/projects/%s/repository/files/LICENSE/raw?ref=HEAD", gitlabAPIURL, projectID)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return License{}, err
+	}
+
+	if authToken := os.Getenv("GITLAB_AUTH_TOKEN"); authToken != "" {
+		req.Header.Set("PRIVATE-TOKEN", authToken)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return License{}, err
+	}
+	defer resp.Body.Close()
+
+	// TODO: Handle error codes (e.g 400, 403, 404, 500, etc)
+
+	content, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return License{}, err
+	}
+
+	// GitLab only gives us raw file content, not an identified SPDX ID, so
+	// we leave SPDXID empty and let the caller run RawContent through a
+	// local license classifier before falling back on a manual review flag.
+	// Source is "api" like GitHub's client: both resolved this license from
+	// the forge's API rather than a local scan of a cloned tree.
+	return License{
+		URL:        endpoint,
+		Source:     "api",
+		RawContent: content,
+	}, nil
+}
+
+func (c *GitLabClient) GetRepoMetadata(ctx context.Context, host, owner, repo string) (RepoMetadata, error) {
+	return RepoMetadata{Owner: owner, Repo: repo, Host: "gitlab.com"}, nil
+}
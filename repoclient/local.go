@@ -0,0 +1,61 @@
+package repoclient
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+
+	"github.com/joshmsamuels/gh-licenses/licensefile"
+)
+
+// LocalClient resolves licenses by shallow-cloning an arbitrary git remote
+// and reading its license file directly, for repos that aren't hosted on a
+// forge this tool otherwise understands.
+type LocalClient struct{}
+
+func NewLocalClient() *LocalClient {
+	return &LocalClient{}
+}
+
+// GetLicense shells out to git to fetch just enough of host/owner/repo (a
+// depth-1 clone) to read its license file, without needing API access to
+// that host at all.
+func (c *LocalClient) GetLicense(ctx context.Context, host, owner, repo string) (License, error) {
+	remoteURL := fmt.Sprintf("https://%s/%s/%s", host, owner, repo)
+
+	tmpDir, err := ioutil.TempDir("", "gh-licenses-")
+	if err != nil {
+		return License{}, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	cmd := exec.CommandContext(ctx, "git", "clone", "--depth", "1", "--quiet", remoteURL, tmpDir)
+	if err := cmd.Run(); err != nil {
+		return License{}, fmt.Errorf("cloning %s: %w", remoteURL, err)
+	}
+
+	path, err := licensefile.Find(tmpDir)
+	if err != nil {
+		return License{}, err
+	}
+	if path == "" {
+		return License{Source: "local"}, nil
+	}
+
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return License{}, err
+	}
+
+	return License{
+		URL:        remoteURL,
+		Source:     "local",
+		RawContent: content,
+	}, nil
+}
+
+func (c *LocalClient) GetRepoMetadata(ctx context.Context, host, owner, repo string) (RepoMetadata, error) {
+	return RepoMetadata{Owner: owner, Repo: repo, Host: host}, nil
+}
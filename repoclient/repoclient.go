@@ -0,0 +1,56 @@
+// Package repoclient abstracts fetching a repo's license away from any one
+// forge, the way ossf/scorecard's RepoClient lets its checks run against
+// GitHub, GitLab, or a local checkout interchangeably.
+package repoclient
+
+import "context"
+
+// License is the result of resolving a single repo's license.
+type License struct {
+	Key  string
+	Name string
+	URL  string
+
+	SPDXID     string
+	Confidence float64
+	Source     string
+
+	// RawContent holds the license file bytes for forges (GitLab,
+	// Bitbucket, local git) whose API only returns file content rather than
+	// an already-identified SPDX ID. Callers that want an SPDXID for these
+	// should run RawContent through a local license classifier.
+	RawContent []byte
+}
+
+// RepoMetadata is basic identifying information about a repo, independent of
+// which forge it lives on.
+type RepoMetadata struct {
+	Owner string
+	Repo  string
+	Host  string
+}
+
+// RepoClient resolves license and metadata information for a repo hosted on
+// a particular forge (GitHub, GitLab, Bitbucket, or an arbitrary git remote).
+// host is the forge the repo was resolved to (e.g. "github.com") - clients
+// for a fixed forge (GitHub, GitLab, Bitbucket) ignore it, but LocalClient
+// needs it to know which remote to clone.
+type RepoClient interface {
+	GetLicense(ctx context.Context, host, owner, repo string) (License, error)
+	GetRepoMetadata(ctx context.Context, host, owner, repo string) (RepoMetadata, error)
+}
+
+// ForHost returns the RepoClient that knows how to talk to host, or nil if
+// the host isn't supported.
+func ForHost(host string) RepoClient {
+	switch host {
+	case "github.com":
+		return NewGitHubClient()
+	case "gitlab.com":
+		return NewGitLabClient()
+	case "bitbucket.org":
+		return NewBitbucketClient()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,28 @@
+package report
+
+import (
+	"encoding/csv"
+	"io"
+	"strconv"
+)
+
+// CSVReporter renders entries as CSV, one row per repo.
+type CSVReporter struct{}
+
+func (CSVReporter) Report(w io.Writer, entries []Entry) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{"repo", "spdx_id", "key", "name", "url", "direct"}); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		row := []string{entry.Repo, entry.SPDXID, entry.Key, entry.Name, entry.URL, strconv.FormatBool(entry.Direct)}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,28 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCSVReporterIncludesDirectColumn(t *testing.T) {
+	var buf strings.Builder
+	entries := []Entry{
+		{Repo: "github.com/a/direct", SPDXID: "MIT", Direct: true},
+	}
+
+	if err := (CSVReporter{}).Report(&buf, entries); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("got %d lines, want 2 (header + row):\n%s", len(lines), buf.String())
+	}
+	if !strings.HasSuffix(lines[0], "direct") {
+		t.Errorf("header = %q, want it to end with %q", lines[0], "direct")
+	}
+	if !strings.HasSuffix(lines[1], "true") {
+		t.Errorf("row = %q, want it to end with %q", lines[1], "true")
+	}
+}
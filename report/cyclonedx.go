@@ -0,0 +1,57 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// CycloneDXReporter emits a CycloneDX 1.5 JSON BOM with one component per
+// repo.
+type CycloneDXReporter struct{}
+
+type cycloneDXBOM struct {
+	BOMFormat   string               `json:"bomFormat"`
+	SpecVersion string               `json:"specVersion"`
+	Version     int                  `json:"version"`
+	Components  []cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXComponent struct {
+	Type     string             `json:"type"`
+	Name     string             `json:"name"`
+	Licenses []cycloneDXLicense `json:"licenses,omitempty"`
+}
+
+type cycloneDXLicense struct {
+	License cycloneDXLicenseID `json:"license"`
+}
+
+type cycloneDXLicenseID struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name,omitempty"`
+}
+
+func (CycloneDXReporter) Report(w io.Writer, entries []Entry) error {
+	bom := cycloneDXBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+	}
+
+	for _, entry := range entries {
+		component := cycloneDXComponent{Type: "library", Name: entry.Repo}
+
+		switch {
+		case entry.SPDXID != "":
+			component.Licenses = []cycloneDXLicense{{License: cycloneDXLicenseID{ID: entry.SPDXID}}}
+		case entry.Name != "":
+			component.Licenses = []cycloneDXLicense{{License: cycloneDXLicenseID{Name: entry.Name}}}
+		}
+
+		bom.Components = append(bom.Components, component)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(bom)
+}
@@ -0,0 +1,42 @@
+package report
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCycloneDXReporter(t *testing.T) {
+	var buf strings.Builder
+	entries := []Entry{
+		{Repo: "github.com/a/b", SPDXID: "MIT"},
+		{Repo: "github.com/c/d", Name: "Some Custom License"},
+		{Repo: "github.com/e/f"},
+	}
+
+	if err := (CycloneDXReporter{}).Report(&buf, entries); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+
+	var bom cycloneDXBOM
+	if err := json.Unmarshal([]byte(buf.String()), &bom); err != nil {
+		t.Fatalf("unmarshaling BOM: %v\noutput: %s", err, buf.String())
+	}
+
+	if bom.BOMFormat != "CycloneDX" || bom.SpecVersion != "1.5" {
+		t.Errorf("bom header = %+v, want CycloneDX/1.5", bom)
+	}
+	if len(bom.Components) != 3 {
+		t.Fatalf("got %d components, want 3", len(bom.Components))
+	}
+
+	if got := bom.Components[0].Licenses[0].License.ID; got != "MIT" {
+		t.Errorf("component[0] license ID = %q, want %q", got, "MIT")
+	}
+	if got := bom.Components[1].Licenses[0].License.Name; got != "Some Custom License" {
+		t.Errorf("component[1] license name = %q, want %q", got, "Some Custom License")
+	}
+	if got := bom.Components[2].Licenses; len(got) != 0 {
+		t.Errorf("component[2] licenses = %+v, want empty", got)
+	}
+}
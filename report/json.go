@@ -0,0 +1,15 @@
+package report
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// JSONReporter renders entries as a JSON array, one object per repo.
+type JSONReporter struct{}
+
+func (JSONReporter) Report(w io.Writer, entries []Entry) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(entries)
+}
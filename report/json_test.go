@@ -0,0 +1,27 @@
+package report
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestJSONReporterRoundTrips(t *testing.T) {
+	var buf strings.Builder
+	entries := []Entry{
+		{Repo: "github.com/a/b", Key: "mit", Name: "MIT License", SPDXID: "MIT", Direct: true},
+	}
+
+	if err := (JSONReporter{}).Report(&buf, entries); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+
+	var got []Entry
+	if err := json.Unmarshal([]byte(buf.String()), &got); err != nil {
+		t.Fatalf("unmarshaling output: %v\noutput: %s", err, buf.String())
+	}
+
+	if len(got) != 1 || got[0] != entries[0] {
+		t.Errorf("got %+v, want %+v", got, entries)
+	}
+}
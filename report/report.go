@@ -0,0 +1,45 @@
+// Package report renders resolved repo/license pairings in the output
+// format a caller needs, so gh-licenses' results can feed a human, a CI log,
+// or a downstream supply-chain scanner.
+package report
+
+import "io"
+
+// Entry is one resolved repo/license pairing a Reporter renders.
+type Entry struct {
+	Repo   string
+	Key    string
+	Name   string
+	URL    string
+	SPDXID string
+
+	// Direct is true when the dependency manifest marked this repo as
+	// directly imported, as opposed to pulled in transitively. It's always
+	// false for repos detected from free-form text rather than a manifest.
+	Direct bool
+}
+
+// Reporter renders a set of resolved license entries to w.
+type Reporter interface {
+	Report(w io.Writer, entries []Entry) error
+}
+
+// ForFormat returns the Reporter for format ("text", "json", "csv", "spdx",
+// or "cyclonedx"), or nil if the format isn't recognized. An empty format
+// defaults to "text".
+func ForFormat(format string) Reporter {
+	switch format {
+	case "", "text":
+		return TextReporter{}
+	case "json":
+		return JSONReporter{}
+	case "csv":
+		return CSVReporter{}
+	case "spdx":
+		return SPDXReporter{}
+	case "cyclonedx":
+		return CycloneDXReporter{}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,27 @@
+package report
+
+import "testing"
+
+func TestForFormat(t *testing.T) {
+	tests := []struct {
+		format string
+		want   Reporter
+	}{
+		{"", TextReporter{}},
+		{"text", TextReporter{}},
+		{"json", JSONReporter{}},
+		{"csv", CSVReporter{}},
+		{"spdx", SPDXReporter{}},
+		{"cyclonedx", CycloneDXReporter{}},
+	}
+
+	for _, tt := range tests {
+		if got := ForFormat(tt.format); got != tt.want {
+			t.Errorf("ForFormat(%q) = %#v, want %#v", tt.format, got, tt.want)
+		}
+	}
+
+	if got := ForFormat("xml"); got != nil {
+		t.Errorf("ForFormat(%q) = %#v, want nil", "xml", got)
+	}
+}
@@ -0,0 +1,51 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// SPDXReporter emits an SPDX 2.3 tag-value document with one Package per
+// repo, suitable for feeding into downstream SBOM tooling.
+type SPDXReporter struct{}
+
+func (SPDXReporter) Report(w io.Writer, entries []Entry) error {
+	fmt.Fprintln(w, "SPDXVersion: SPDX-2.3")
+	fmt.Fprintln(w, "DataLicense: CC0-1.0")
+	fmt.Fprintln(w, "SPDXID: SPDXRef-DOCUMENT")
+	fmt.Fprintln(w, "DocumentName: gh-licenses-report")
+	fmt.Fprintln(w, "DocumentNamespace: https://github.com/joshmsamuels/gh-licenses/spdxdocs/gh-licenses-report")
+	fmt.Fprintln(w, "Creator: Tool: gh-licenses")
+	fmt.Fprintln(w)
+
+	for _, entry := range entries {
+		spdxID := entry.SPDXID
+		if spdxID == "" {
+			spdxID = "NOASSERTION"
+		}
+
+		fmt.Fprintf(w, "PackageName: %s\n", entry.Repo)
+		fmt.Fprintf(w, "SPDXID: SPDXRef-Package-%s\n", spdxRefSafe(entry.Repo))
+		fmt.Fprintf(w, "PackageDownloadLocation: https://%s\n", entry.Repo)
+		fmt.Fprintf(w, "PackageLicenseConcluded: %s\n", spdxID)
+		fmt.Fprintln(w, "PackageLicenseDeclared: NOASSERTION")
+		fmt.Fprintln(w, "FilesAnalyzed: false")
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
+
+// spdxRefSafe replaces characters an SPDXID token can't contain (everything
+// but letters, digits and '.') with a dash.
+func spdxRefSafe(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '.':
+			return r
+		default:
+			return '-'
+		}
+	}, s)
+}
@@ -0,0 +1,40 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSPDXReporter(t *testing.T) {
+	var buf strings.Builder
+	entries := []Entry{
+		{Repo: "github.com/a/b", SPDXID: "MIT"},
+		{Repo: "github.com/c/d"},
+	}
+
+	if err := (SPDXReporter{}).Report(&buf, entries); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+
+	out := buf.String()
+	if !strings.Contains(out, "SPDXVersion: SPDX-2.3") {
+		t.Errorf("missing SPDX document header:\n%s", out)
+	}
+	if !strings.Contains(out, "PackageLicenseConcluded: MIT") {
+		t.Errorf("missing resolved license:\n%s", out)
+	}
+	if !strings.Contains(out, "PackageLicenseConcluded: NOASSERTION") {
+		t.Errorf("missing NOASSERTION fallback for unresolved license:\n%s", out)
+	}
+	if !strings.Contains(out, "SPDXRef-Package-github.com-a-b") {
+		t.Errorf("package SPDXID not made ref-safe:\n%s", out)
+	}
+}
+
+func TestSpdxRefSafe(t *testing.T) {
+	got := spdxRefSafe("github.com/a/b_c")
+	want := "github.com-a-b-c"
+	if got != want {
+		t.Errorf("spdxRefSafe = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"fmt"
+	"io"
+)
+
+// TextReporter reproduces gh-licenses' original human-readable output:
+// one license block per distinct license, listing the repos under it.
+type TextReporter struct{}
+
+func (TextReporter) Report(w io.Writer, entries []Entry) error {
+	byLicense := make(map[string][]Entry)
+	var order []string
+
+	for _, entry := range entries {
+		key := entry.Name + "|" + entry.Key
+		if _, ok := byLicense[key]; !ok {
+			order = append(order, key)
+		}
+		byLicense[key] = append(byLicense[key], entry)
+	}
+
+	for _, key := range order {
+		group := byLicense[key]
+		first := group[0]
+
+		fmt.Fprintf(w, "Name: %s | Key: %s | URL: %s\n", first.Name, first.Key, first.URL)
+
+		fmt.Fprintf(w, "Repos: ")
+		for i, entry := range group {
+			if i > 0 {
+				fmt.Fprintf(w, ", ")
+			}
+			fmt.Fprintf(w, "%s", entry.Repo)
+			if !entry.Direct {
+				fmt.Fprintf(w, " (indirect)")
+			}
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintln(w)
+	}
+
+	return nil
+}
@@ -0,0 +1,26 @@
+package report
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextReporterMarksIndirectRepos(t *testing.T) {
+	var buf strings.Builder
+	entries := []Entry{
+		{Repo: "github.com/a/direct", Name: "MIT License", Key: "mit", Direct: true},
+		{Repo: "github.com/b/indirect", Name: "MIT License", Key: "mit", Direct: false},
+	}
+
+	if err := (TextReporter{}).Report(&buf, entries); err != nil {
+		t.Fatalf("Report: %v", err)
+	}
+
+	out := buf.String()
+	if strings.Contains(out, "github.com/a/direct (indirect)") {
+		t.Errorf("direct repo incorrectly marked indirect:\n%s", out)
+	}
+	if !strings.Contains(out, "github.com/b/indirect (indirect)") {
+		t.Errorf("indirect repo not marked indirect:\n%s", out)
+	}
+}